@@ -0,0 +1,137 @@
+package positronic
+
+// Histogram is a single window's bucket counts produced by
+// HistogramOverTime, alongside the bucket boundaries used to build it.
+type Histogram struct {
+	Buckets []float64
+	Counts  []int
+}
+
+// windowBounds splits n timeline entries into non-overlapping windows of
+// `window` entries, returning each window's [start, end) bounds.
+func windowBounds(n, window int) [][2]int {
+	if window <= 0 {
+		return nil
+	}
+	var bounds [][2]int
+	for start := 0; start < n; start += window {
+		end := start + window
+		if end > n {
+			end = n
+		}
+		bounds = append(bounds, [2]int{start, end})
+	}
+	return bounds
+}
+
+// CountOverTime returns, for each window of `window` timeline entries, how
+// many non-nil values were assigned within it. The timeline holds the initial
+// value, one entry per Assign, and one entry per step a WithParallel or
+// WithSequential block advanced; windows therefore count entries, not
+// RunProgram iterations, and match iterations only when the program assigns
+// exactly once per iteration. Once the variable has converged, the per-window
+// counts within the detected cycle are guaranteed to repeat.
+func (pv *PositronicVariable) CountOverTime(window int) []int {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	var counts []int
+	for _, b := range windowBounds(len(pv.timeline), window) {
+		count := 0
+		for _, tl := range pv.timeline[b[0]:b[1]] {
+			for _, v := range tl {
+				if v != nil {
+					count++
+				}
+			}
+		}
+		counts = append(counts, count)
+	}
+	return counts
+}
+
+// DistinctOverTime returns, for each window of `window` timeline entries
+// (windowed as in CountOverTime), the number of distinct values within it.
+func (pv *PositronicVariable) DistinctOverTime(window int) []int {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	var distinct []int
+	for _, b := range windowBounds(len(pv.timeline), window) {
+		seen := make(map[interface{}]struct{})
+		for _, tl := range pv.timeline[b[0]:b[1]] {
+			for _, v := range tl {
+				seen[v] = struct{}{}
+			}
+		}
+		distinct = append(distinct, len(seen))
+	}
+	return distinct
+}
+
+// HistogramOverTime buckets the numeric values within each window of `window`
+// timeline entries into the given bucket boundaries (values >= the last
+// boundary land in a final overflow bucket).
+func (pv *PositronicVariable) HistogramOverTime(window int, buckets []float64) []Histogram {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	var result []Histogram
+	for _, b := range windowBounds(len(pv.timeline), window) {
+		counts := make([]int, len(buckets)+1)
+		for _, tl := range pv.timeline[b[0]:b[1]] {
+			for _, v := range tl {
+				f, ok := toFloat(v)
+				if !ok {
+					continue
+				}
+				counts[bucketIndex(f, buckets)]++
+			}
+		}
+		result = append(result, Histogram{Buckets: buckets, Counts: counts})
+	}
+	return result
+}
+
+func bucketIndex(v float64, buckets []float64) int {
+	for i, b := range buckets {
+		if v < b {
+			return i
+		}
+	}
+	return len(buckets)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// Reduce runs fn over the values in each window of `window` timeline entries,
+// returning one reduced result per window. It generalizes CountOverTime,
+// DistinctOverTime and HistogramOverTime to arbitrary analytical queries over
+// the timeline without collapsing it to a single superposition.
+func (pv *PositronicVariable) Reduce(window int, fn func([]interface{}) interface{}) []interface{} {
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+
+	var results []interface{}
+	for _, b := range windowBounds(len(pv.timeline), window) {
+		var values []interface{}
+		for _, tl := range pv.timeline[b[0]:b[1]] {
+			values = append(values, tl...)
+		}
+		results = append(results, fn(values))
+	}
+	return results
+}
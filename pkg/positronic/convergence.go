@@ -0,0 +1,99 @@
+package positronic
+
+// ConvergenceDetector decides whether a sequence of timeline head values has
+// settled into a repeating cycle and, if so, how long that cycle is.
+type ConvergenceDetector interface {
+	Detect(sequence []interface{}) (converged bool, cycleLength int)
+}
+
+// DefaultDetector reproduces the library's original O(n*k) suffix-compare
+// behavior, capped at MaxCycleLength. It's kept for back-compat: RunProgram
+// still uses it unless a different detector is installed with
+// WithConvergenceDetector, passed to NewPositronicVariable or RunProgramWith.
+type DefaultDetector struct {
+	MaxCycleLength int
+}
+
+// Detect implements ConvergenceDetector by comparing the trailing cycleLen
+// values against the cycleLen values before them, for every candidate cycle
+// length up to MaxCycleLength.
+func (d DefaultDetector) Detect(sequence []interface{}) (bool, int) {
+	max := d.MaxCycleLength
+	if max <= 0 {
+		max = 10
+	}
+
+	n := len(sequence)
+	for cycleLen := 1; cycleLen <= max && cycleLen*2 <= n; cycleLen++ {
+		match := true
+		for i := 0; i < cycleLen; i++ {
+			if sequence[n-1-i] != sequence[n-1-i-cycleLen] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true, cycleLen
+		}
+	}
+	return false, 0
+}
+
+// BrentDetector finds cycles of arbitrary length in O(n) time and O(1) extra
+// state using Brent's teleporting-hare algorithm: it keeps a "tortoise"
+// snapshot and a doubling power λ, comparing every new value against the
+// tortoise and re-snapshotting whenever the steps since the last snapshot
+// reach λ. Equal lets callers compare non-hashable/interface{} states; it
+// defaults to Go's built-in == when left nil.
+type BrentDetector struct {
+	Equal func(a, b interface{}) bool
+}
+
+// Detect implements ConvergenceDetector using Brent's algorithm.
+func (d BrentDetector) Detect(sequence []interface{}) (bool, int) {
+	if len(sequence) == 0 {
+		return false, 0
+	}
+
+	equal := d.Equal
+	if equal == nil {
+		equal = func(a, b interface{}) bool { return a == b }
+	}
+
+	tortoise := sequence[0]
+	power := 1
+	stepsSinceSnapshot := 0
+
+	for _, value := range sequence[1:] {
+		stepsSinceSnapshot++
+		if equal(value, tortoise) {
+			return true, stepsSinceSnapshot
+		}
+		if stepsSinceSnapshot == power {
+			power *= 2
+			stepsSinceSnapshot = 0
+			tortoise = value
+		}
+	}
+	return false, 0
+}
+
+// RunOption configures a PositronicVariable, either at construction time via
+// NewPositronicVariable or per-run via RunProgramWith.
+type RunOption func(*PositronicVariable)
+
+// WithConvergenceDetector installs the ConvergenceDetector RunProgram and
+// RunProgramWith should use instead of DefaultDetector.
+func WithConvergenceDetector(d ConvergenceDetector) RunOption {
+	return func(pv *PositronicVariable) { pv.detector = d }
+}
+
+// RunProgramWith behaves like RunProgram but applies opts first, letting
+// callers swap in a ConvergenceDetector (or any other RunOption). The options
+// stay installed on pv after the run; they are not reverted.
+func (pv *PositronicVariable) RunProgramWith(program func(*PositronicVariable), opts ...RunOption) {
+	for _, opt := range opts {
+		opt(pv)
+	}
+	pv.RunProgram(program)
+}
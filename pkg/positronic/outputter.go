@@ -0,0 +1,135 @@
+package positronic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Outputter receives every Output call as it happens and the final
+// superpositions once RunProgram converges, decoupling PositronicVariable
+// from any particular sink (stdout, a buffer, JSON, a live progress UI).
+type Outputter interface {
+	Emit(iteration int, entropy int, format string, args ...interface{})
+	Flush(finalSuperpositions map[string]interface{})
+}
+
+// PrintfOutputter is the default Outputter. It reproduces the library's
+// original behavior: individual Output calls aren't printed as they happen,
+// only the final per-format superpositions are, via fmt.Printf.
+type PrintfOutputter struct{}
+
+// Emit is a no-op, matching the original behavior of only printing on Flush.
+func (PrintfOutputter) Emit(iteration, entropy int, format string, args ...interface{}) {}
+
+// Flush prints each format string with its superposed argument via fmt.Printf.
+func (PrintfOutputter) Flush(finalSuperpositions map[string]interface{}) {
+	for format, arg := range finalSuperpositions {
+		fmt.Printf(format, arg)
+	}
+}
+
+// BufferedOutputter captures emitted lines and the final Flush payload in
+// memory instead of printing them, so tests can assert on output without
+// capturing stdout.
+type BufferedOutputter struct {
+	mu    sync.Mutex
+	Lines []string
+	Final map[string]interface{}
+}
+
+// Emit records the formatted line.
+func (b *BufferedOutputter) Emit(iteration, entropy int, format string, args ...interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.Lines = append(b.Lines, fmt.Sprintf(format, args...))
+}
+
+// Flush records the final superpositions.
+func (b *BufferedOutputter) Flush(finalSuperpositions map[string]interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.Final = finalSuperpositions
+}
+
+// jsonOutputLine is a single emitted line as written by JSONOutputter.
+type jsonOutputLine struct {
+	Iteration int    `json:"iteration"`
+	Entropy   int    `json:"entropy"`
+	Line      string `json:"line"`
+}
+
+// JSONOutputter writes each emitted line, and the final Flush payload, as
+// JSON to W, for machine consumption.
+type JSONOutputter struct {
+	W  io.Writer
+	mu sync.Mutex
+}
+
+// Emit writes a jsonOutputLine for the formatted output.
+func (j *JSONOutputter) Emit(iteration, entropy int, format string, args ...interface{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := json.Marshal(jsonOutputLine{Iteration: iteration, Entropy: entropy, Line: fmt.Sprintf(format, args...)})
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(j.W, string(data))
+}
+
+// Flush writes the final superpositions as a single JSON object.
+func (j *JSONOutputter) Flush(finalSuperpositions map[string]interface{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := json.Marshal(finalSuperpositions)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(j.W, string(data))
+}
+
+var progressSpinnerFrames = []rune{'|', '/', '-', '\\'}
+
+// ProgressOutputter renders each iteration collapsing forward/backward with
+// an in-place spinner, styled after dagger's per-step progress UI, then
+// prints the final any(...) superposition per format string on Flush.
+type ProgressOutputter struct {
+	W     io.Writer
+	mu    sync.Mutex
+	frame int
+}
+
+// Emit redraws the spinner line in place for the current iteration.
+func (p *ProgressOutputter) Emit(iteration, entropy int, format string, args ...interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	direction := "forward"
+	if entropy < 0 {
+		direction = "backward"
+	}
+
+	frame := progressSpinnerFrames[p.frame%len(progressSpinnerFrames)]
+	p.frame++
+	fmt.Fprintf(p.W, "\r%c iteration %d (%s)", frame, iteration, direction)
+}
+
+// Flush clears the spinner line and prints the final superpositions.
+func (p *ProgressOutputter) Flush(finalSuperpositions map[string]interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprint(p.W, "\r\n")
+	for format, arg := range finalSuperpositions {
+		fmt.Fprintf(p.W, format, arg)
+	}
+}
+
+// WithOutputter installs the Outputter RunProgram/RunProgramWith should use
+// in place of the default PrintfOutputter.
+func WithOutputter(o Outputter) RunOption {
+	return func(pv *PositronicVariable) { pv.outputter = o }
+}
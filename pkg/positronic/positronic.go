@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 	"github.com/hutchpd/QuantumSuperPosition-Go/pkg/quantum"
+	"github.com/hutchpd/positronic-variables/pkg/positronic/trace"
 )
 
 // OutputEntry represents a single timeline of a positronic variable
@@ -17,16 +18,27 @@ type PositronicVariable struct {
 	timeline    [][]interface{}
 	convergence bool
 	mu          sync.Mutex
-	outputLogs [][]OutputEntry 
+	outputLogs [][]OutputEntry
 	iteration   int
+	entropy     int
+	detector    ConvergenceDetector
+	tracer      trace.Writer
+	outputter   Outputter
 }
 
-// NewPositronicVariable initializes a new positronic variable
-func NewPositronicVariable(initialValue interface{}) *PositronicVariable {
-	return &PositronicVariable{
+// NewPositronicVariable initializes a new positronic variable. opts can
+// install a non-default Outputter, ConvergenceDetector, or trace.Writer;
+// RunProgramWith accepts the same RunOption type to override them later.
+func NewPositronicVariable(initialValue interface{}, opts ...RunOption) *PositronicVariable {
+	pv := &PositronicVariable{
 		timeline:   [][]interface{}{{initialValue}},
 		outputLogs: [][]OutputEntry{},
+		outputter:  PrintfOutputter{},
 	}
+	for _, opt := range opts {
+		opt(pv)
+	}
+	return pv
 }
 
 // Reinitialize resets the positronic variable to the initial value
@@ -59,6 +71,19 @@ func (pv *PositronicVariable) Output(format string, args ...interface{}) {
         pv.outputLogs[len(pv.outputLogs)-1],
         OutputEntry{format: format, args: args},
     )
+
+    if pv.tracer != nil {
+        pv.tracer.WriteEvent(trace.Event{
+            Iteration: pv.iteration,
+            Entropy:   pv.entropy,
+            Kind:      trace.EventOutput,
+            Format:    format,
+            Args:      args,
+        })
+    }
+    if pv.outputter != nil {
+        pv.outputter.Emit(pv.iteration, pv.entropy, format, args...)
+    }
 }
 
 
@@ -69,6 +94,15 @@ func (pv *PositronicVariable) Assign(value interface{}) {
 
 	// Always append the new value to the timeline
 	pv.timeline = append(pv.timeline, []interface{}{value})
+
+	if pv.tracer != nil {
+		pv.tracer.WriteEvent(trace.Event{
+			Iteration: pv.iteration,
+			Entropy:   pv.entropy,
+			Kind:      trace.EventAssign,
+			Args:      []interface{}{value},
+		})
+	}
 }
 
 // CurrentState returns the current state of the positronic variable
@@ -100,10 +134,16 @@ func (pv *PositronicVariable) RunProgram(program func(*PositronicVariable)) {
     for iterations := 0; iterations < maxIterations; iterations++ {
         pv.mu.Lock()
         pv.iteration = iterations // Set iteration count
+        pv.entropy = entropy
         // Start a new output log entry for this iteration
         pv.outputLogs = append(pv.outputLogs, []OutputEntry{})
+        tracer := pv.tracer
         pv.mu.Unlock()
 
+        if tracer != nil {
+            tracer.WriteEvent(trace.Event{Iteration: iterations, Entropy: entropy, Kind: trace.EventIteration})
+        }
+
         // Run the program, passing the current entropy
         program(pv)
 
@@ -114,6 +154,9 @@ func (pv *PositronicVariable) RunProgram(program func(*PositronicVariable)) {
                 pv.convergence = true
                 pv.createSuperpositions()
                 cycleLen = cl
+                if tracer != nil {
+                    tracer.WriteEvent(trace.Event{Iteration: iterations, Entropy: entropy, Kind: trace.EventConvergence, CycleLength: cl})
+                }
                 break // Convergence achieved
             }
         }
@@ -126,28 +169,24 @@ func (pv *PositronicVariable) RunProgram(program func(*PositronicVariable)) {
     pv.processOutputs(cycleLen)
 }
 
-// checkConvergence checks if the timelines have converged
+// checkConvergence checks if the timelines have converged, delegating to the
+// variable's ConvergenceDetector (DefaultDetector{MaxCycleLength: 10} unless
+// one was installed with WithConvergenceDetector).
 func (pv *PositronicVariable) checkConvergence() (bool, int) {
 	pv.mu.Lock()
-	defer pv.mu.Unlock()
-
-	n := len(pv.timeline)
-	maxCycleLength := 10 // Define a reasonable maximum cycle length
-
-	// Start checking from cycle length 1 up to maxCycleLength
-	for cycleLen := 1; cycleLen <= maxCycleLength && cycleLen*2 <= n; cycleLen++ {
-		match := true
-		for i := 0; i < cycleLen; i++ {
-			if pv.timeline[n-1-i][0] != pv.timeline[n-1-i-cycleLen][0] {
-				match = false
-				break
-			}
-		}
-		if match {
-			return true, cycleLen // Convergence detected, return cycle length
+	sequence := make([]interface{}, 0, len(pv.timeline))
+	for _, tl := range pv.timeline {
+		if len(tl) > 0 {
+			sequence = append(sequence, tl[0])
 		}
 	}
-	return false, 0 // No convergence detected
+	detector := pv.detector
+	pv.mu.Unlock()
+
+	if detector == nil {
+		detector = DefaultDetector{MaxCycleLength: 10}
+	}
+	return detector.Detect(sequence)
 }
 
 
@@ -207,14 +246,8 @@ func (pv *PositronicVariable) processOutputs(cycleLen int) {
         }
     }
 
-    // Prepare data to print after unlocking
-    type printData struct {
-        format   string
-        superArg interface{}
-    }
-    var printList []printData
-
-    // For each format string, create superposition of arguments
+    // For each format string, create a superposition of its arguments
+    finalSuperpositions := make(map[string]interface{})
     for format, argsList := range outputMap {
         // Remove duplicates
         argsSet := make(map[interface{}]struct{})
@@ -227,21 +260,16 @@ func (pv *PositronicVariable) processOutputs(cycleLen int) {
             uniqueArgs = append(uniqueArgs, arg)
         }
 
-        superArgs := quantum.Any(uniqueArgs...)
-
-        // Collect the data to print
-        printList = append(printList, printData{
-            format:   format,
-            superArg: superArgs,
-        })
+        finalSuperpositions[format] = quantum.Any(uniqueArgs...)
     }
 
-    pv.mu.Unlock() // Release the mutex before printing
+    outputter := pv.outputter
+    pv.mu.Unlock() // Release the mutex before flushing
 
-    // Now print the outputs
-    for _, pd := range printList {
-        fmt.Printf(pd.format, pd.superArg)
+    if outputter == nil {
+        outputter = PrintfOutputter{}
     }
+    outputter.Flush(finalSuperpositions)
 }
 
 // String implements the Stringer interface for PositronicVariable
@@ -0,0 +1,100 @@
+package positronic
+
+import "github.com/hutchpd/QuantumSuperPosition-Go/pkg/quantum"
+
+// timelineBlock captures what a nested WithSequential/WithParallel step
+// produced against its own scratch timeline: the values it assigned, and how
+// far its cursor advanced (its end "mu", borrowing ARTIQ's timeline-block
+// terminology).
+type timelineBlock struct {
+	values []interface{}
+	mu     int
+}
+
+// runBlock executes steps against a scratch PositronicVariable seeded with
+// pv's current state, so nested blocks never race on pv's own timeline
+// cursor. The scratch variable inherits pv's tracer, outputter, iteration and
+// entropy so Assign/Output calls made from inside a block still reach the
+// tracer and the outputter's Emit. Output calls made inside a block are not
+// added to pv's output logs, so they take no part in the final superpositions.
+func (pv *PositronicVariable) runBlock(steps []func(*PositronicVariable)) *timelineBlock {
+	pv.mu.Lock()
+	var currentState interface{}
+	if n := len(pv.timeline); n > 0 && len(pv.timeline[n-1]) > 0 {
+		currentState = pv.timeline[n-1][0]
+	}
+	scratch := &PositronicVariable{
+		timeline:  [][]interface{}{{currentState}},
+		tracer:    pv.tracer,
+		outputter: pv.outputter,
+		iteration: pv.iteration,
+		entropy:   pv.entropy,
+	}
+	pv.mu.Unlock()
+
+	for _, step := range steps {
+		step(scratch)
+	}
+
+	block := &timelineBlock{mu: len(scratch.timeline) - 1}
+	for _, tl := range scratch.timeline[1:] {
+		if len(tl) > 0 {
+			block.values = append(block.values, tl[0])
+		}
+	}
+	return block
+}
+
+// WithSequential runs the steps in order against one shared scratch timeline
+// seeded from pv's current state, so each step sees the assignments of the
+// steps before it. On exit, the block's assignments
+// are appended to pv's timeline in order, so the cursor advances additively
+// by the number of assignments made.
+func (pv *PositronicVariable) WithSequential(steps ...func(*PositronicVariable)) {
+	block := pv.runBlock(steps)
+
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+	for _, v := range block.values {
+		pv.timeline = append(pv.timeline, []interface{}{v})
+	}
+}
+
+// WithParallel runs each branch against its own scratch timeline, as if the
+// antivals evolved concurrently within this iteration. On exit, pv's cursor
+// advances to max(mu) across branches: for each step up to that max, the
+// union of every branch's value at that step (branches that finished earlier
+// simply contribute nothing further) is folded into a single superposition
+// via quantum.Any and appended as one timeline entry aligned to that step.
+// This keeps checkConvergence comparing aligned parallel frontiers rather
+// than interleaved individual assigns, even when branches take a different
+// number of steps.
+func (pv *PositronicVariable) WithParallel(branches ...func(*PositronicVariable)) {
+	blocks := make([]*timelineBlock, len(branches))
+	maxMu := 0
+	for i, branch := range branches {
+		blocks[i] = pv.runBlock([]func(*PositronicVariable){branch})
+		if blocks[i].mu > maxMu {
+			maxMu = blocks[i].mu
+		}
+	}
+
+	if maxMu == 0 {
+		return
+	}
+
+	pv.mu.Lock()
+	defer pv.mu.Unlock()
+	for step := 0; step < maxMu; step++ {
+		var frontier []interface{}
+		for _, block := range blocks {
+			if step < len(block.values) {
+				frontier = append(frontier, block.values[step])
+			}
+		}
+		if len(frontier) == 0 {
+			continue
+		}
+		pv.timeline = append(pv.timeline, []interface{}{quantum.Any(frontier...)})
+	}
+}
@@ -0,0 +1,129 @@
+// Package trace turns a PositronicVariable run into a structured event
+// stream instead of opaque fmt.Printf debugging, so forward/backward
+// oscillation and eventual convergence can be inspected after the fact.
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// EventKind identifies the kind of structured trace event.
+type EventKind string
+
+const (
+	EventIteration   EventKind = "iteration"
+	EventAssign      EventKind = "assign"
+	EventOutput      EventKind = "output"
+	EventConvergence EventKind = "convergence"
+)
+
+// Event is a single structured trace event emitted during a RunProgram run.
+type Event struct {
+	Iteration   int           `json:"iteration"`
+	Entropy     int           `json:"entropy"`
+	Kind        EventKind     `json:"kind"`
+	Format      string        `json:"format,omitempty"`
+	Args        []interface{} `json:"args,omitempty"`
+	CycleLength int           `json:"cycleLength,omitempty"`
+}
+
+// Writer emits Events to some sink.
+type Writer interface {
+	WriteEvent(Event) error
+}
+
+// jsonlWriter emits one compact JSON object per line, suited to jq/log
+// pipelines.
+type jsonlWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLWriter returns a Writer that emits one JSON object per line.
+func NewJSONLWriter(w io.Writer) Writer {
+	return &jsonlWriter{w: w}
+}
+
+func (j *jsonlWriter) WriteEvent(e Event) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(j.w, string(data))
+	return err
+}
+
+// chromeEvent is a single entry in Chrome's trace-event JSON format, as
+// understood by chrome://tracing and go tool trace's viewer.
+type chromeEvent struct {
+	Name string                 `json:"name"`
+	Cat  string                 `json:"cat"`
+	Ph   string                 `json:"ph"`
+	Ts   int64                  `json:"ts"`
+	Pid  int                    `json:"pid"`
+	Tid  int                    `json:"tid"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// ChromeTraceWriter accumulates events and renders them as a Chrome-tracing
+// document on Close. Forward iterations are rendered on one lane (tid 0),
+// backward iterations on another (tid 1), with convergence events emitted as
+// instant markers so cycle boundaries are visible in the timeline.
+type ChromeTraceWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	start  time.Time
+	events []chromeEvent
+}
+
+// NewChromeTraceWriter returns a Writer that buffers events and renders them
+// as Chrome-tracing JSON when Close is called.
+func NewChromeTraceWriter(w io.Writer) *ChromeTraceWriter {
+	return &ChromeTraceWriter{w: w, start: time.Now()}
+}
+
+func (c *ChromeTraceWriter) WriteEvent(e Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tid := 0 // forward lane
+	if e.Entropy < 0 {
+		tid = 1 // backward lane
+	}
+
+	ph := "X"
+	if e.Kind == EventConvergence {
+		ph = "i" // instant event marking a cycle boundary
+	}
+
+	c.events = append(c.events, chromeEvent{
+		Name: string(e.Kind),
+		Cat:  "positronic",
+		Ph:   ph,
+		Ts:   time.Since(c.start).Microseconds(),
+		Pid:  1,
+		Tid:  tid,
+		Args: map[string]interface{}{
+			"iteration":   e.Iteration,
+			"format":      e.Format,
+			"args":        e.Args,
+			"cycleLength": e.CycleLength,
+		},
+	})
+	return nil
+}
+
+// Close flushes the accumulated events as a single {"traceEvents": [...]}
+// document.
+func (c *ChromeTraceWriter) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return json.NewEncoder(c.w).Encode(map[string]interface{}{"traceEvents": c.events})
+}
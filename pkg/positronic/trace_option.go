@@ -0,0 +1,10 @@
+package positronic
+
+import "github.com/hutchpd/positronic-variables/pkg/positronic/trace"
+
+// WithTrace installs a trace.Writer that receives a structured event stream
+// of every iteration, Assign/Output call, and the final convergence event as
+// RunProgram executes, in place of opaque fmt.Printf debugging.
+func WithTrace(w trace.Writer) RunOption {
+	return func(pv *PositronicVariable) { pv.tracer = w }
+}
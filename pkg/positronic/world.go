@@ -0,0 +1,224 @@
+package positronic
+
+import (
+	"sync"
+
+	"github.com/hutchpd/QuantumSuperPosition-Go/pkg/quantum"
+)
+
+// registeredVariable pairs a PositronicVariable with the name it was
+// registered under, purely for reporting in a ConvergenceReport.
+type registeredVariable struct {
+	name string
+	pv   *PositronicVariable
+}
+
+// World owns a set of registered PositronicVariables and drives them through
+// shared forward/backward iterations, only declaring convergence once every
+// registered variable's timeline has converged simultaneously.
+type World struct {
+	mu        sync.Mutex
+	variables []*registeredVariable
+}
+
+// WorldOption configures a World.RunProgram call.
+type WorldOption func(*worldOptions)
+
+type worldOptions struct {
+	maxIterations  int
+	maxCycleLength int
+	outputter      Outputter
+}
+
+func defaultWorldOptions() *worldOptions {
+	return &worldOptions{maxIterations: 100, maxCycleLength: 10, outputter: PrintfOutputter{}}
+}
+
+// MaxIterations caps the number of forward/backward passes the World will run
+// before giving up on finding a joint fixpoint.
+func MaxIterations(n int) WorldOption {
+	return func(o *worldOptions) { o.maxIterations = n }
+}
+
+// MaxCycleLength caps the per-variable cycle length considered while checking
+// for convergence.
+func MaxCycleLength(n int) WorldOption {
+	return func(o *worldOptions) { o.maxCycleLength = n }
+}
+
+// WithWorldOutputter installs the Outputter the World should flush its joint,
+// cross-variable superpositions through instead of the default
+// PrintfOutputter, mirroring WithOutputter on a single PositronicVariable.
+func WithWorldOutputter(o Outputter) WorldOption {
+	return func(wo *worldOptions) { wo.outputter = o }
+}
+
+// NewWorld creates an empty World ready to have variables registered with it.
+func NewWorld() *World {
+	return &World{}
+}
+
+// Register adds a PositronicVariable to the World under the given name. The
+// name only exists for reporting: it shows up as a key in ConvergenceReport.
+func (w *World) Register(name string, pv *PositronicVariable) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.variables = append(w.variables, &registeredVariable{name: name, pv: pv})
+}
+
+// VariableConvergence reports how a single registered variable behaved during
+// a World run.
+type VariableConvergence struct {
+	CycleLength int
+	Converged   bool
+}
+
+// ConvergenceReport summarizes the outcome of a World.RunProgram call.
+type ConvergenceReport struct {
+	Iterations       int
+	Converged        bool
+	JointCycleLength int
+	PerVariable      map[string]VariableConvergence
+}
+
+// RunProgram drives every registered variable's shared program through
+// forward/backward iterations until all of their timelines converge at once.
+// The joint cycle is the LCM of each variable's individually detected cycle
+// length. Output logs are aggregated across the World so the joint
+// superpositions it builds can correlate tuples across variables rather than
+// each variable only ever seeing its own outputs.
+func (w *World) RunProgram(program func(*World, int), opts ...WorldOption) *ConvergenceReport {
+	o := defaultWorldOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for _, rv := range w.variables {
+		rv.pv.Reinitialize(rv.pv.timeline[0][0])
+		if rv.pv.detector == nil {
+			rv.pv.detector = DefaultDetector{MaxCycleLength: o.maxCycleLength}
+		}
+	}
+
+	entropy := 1
+	report := &ConvergenceReport{PerVariable: make(map[string]VariableConvergence)}
+	var jointCycleLength int
+
+	for iterations := 0; iterations < o.maxIterations; iterations++ {
+		report.Iterations = iterations + 1
+
+		for _, rv := range w.variables {
+			rv.pv.mu.Lock()
+			rv.pv.iteration = iterations
+			rv.pv.entropy = entropy
+			rv.pv.outputLogs = append(rv.pv.outputLogs, []OutputEntry{})
+			rv.pv.mu.Unlock()
+		}
+
+		program(w, entropy)
+
+		if entropy < 0 {
+			allConverged := true
+			perVar := make(map[string]VariableConvergence, len(w.variables))
+			cycles := make([]int, 0, len(w.variables))
+
+			for _, rv := range w.variables {
+				converged, cl := rv.pv.checkConvergence()
+				perVar[rv.name] = VariableConvergence{CycleLength: cl, Converged: converged}
+				if !converged || cl > o.maxCycleLength {
+					allConverged = false
+					continue
+				}
+				cycles = append(cycles, cl)
+			}
+
+			if allConverged {
+				jointCycleLength = lcmAll(cycles)
+				report.PerVariable = perVar
+				report.JointCycleLength = jointCycleLength
+				report.Converged = true
+
+				for _, rv := range w.variables {
+					rv.pv.createSuperpositions()
+				}
+				break
+			}
+			report.PerVariable = perVar
+		}
+
+		entropy = -entropy
+	}
+
+	w.processOutputs(jointCycleLength, o.outputter)
+	return report
+}
+
+// processOutputs aggregates the last joint-cycle worth of output logs across
+// every registered variable and, for each distinct format string, flushes a
+// superposition built from every variable's arguments for it through
+// outputter. This is what lets a World build joint superpositions of
+// correlated tuples instead of each variable only ever reporting on itself,
+// without hardcoding a sink that tests can't intercept.
+func (w *World) processOutputs(cycleLen int, outputter Outputter) {
+	if cycleLen == 0 {
+		return
+	}
+	if outputter == nil {
+		outputter = PrintfOutputter{}
+	}
+
+	outputMap := make(map[string][]interface{})
+
+	for _, rv := range w.variables {
+		rv.pv.mu.Lock()
+		n := len(rv.pv.outputLogs)
+		if n >= cycleLen {
+			for i := n - cycleLen; i < n; i++ {
+				for _, entry := range rv.pv.outputLogs[i] {
+					outputMap[entry.format] = append(outputMap[entry.format], entry.args...)
+				}
+			}
+		}
+		rv.pv.mu.Unlock()
+	}
+
+	finalSuperpositions := make(map[string]interface{})
+	for format, argsList := range outputMap {
+		argsSet := make(map[interface{}]struct{})
+		for _, arg := range argsList {
+			argsSet[arg] = struct{}{}
+		}
+		var uniqueArgs []interface{}
+		for arg := range argsSet {
+			uniqueArgs = append(uniqueArgs, arg)
+		}
+		finalSuperpositions[format] = quantum.Any(uniqueArgs...)
+	}
+
+	outputter.Flush(finalSuperpositions)
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func lcm(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return a / gcd(a, b) * b
+}
+
+func lcmAll(nums []int) int {
+	if len(nums) == 0 {
+		return 0
+	}
+	result := nums[0]
+	for _, n := range nums[1:] {
+		result = lcm(result, n)
+	}
+	return result
+}
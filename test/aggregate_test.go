@@ -0,0 +1,65 @@
+package positronic_test
+
+import (
+	"testing"
+
+	"github.com/hutchpd/positronic-variables/pkg/positronic"
+)
+
+func TestCountOverTimeCountsNonNilAssignedValues(t *testing.T) {
+	pv := positronic.NewPositronicVariable(0)
+	pv.Assign(1)
+	pv.Assign(nil)
+	pv.Assign(2)
+
+	// timeline: [0] [1] [nil] [2], windows of 2: {0,1}->2 non-nil, {nil,2}->1 non-nil
+	counts := pv.CountOverTime(2)
+	if len(counts) != 2 || counts[0] != 2 || counts[1] != 1 {
+		t.Errorf("expected counts [2 1], got %v", counts)
+	}
+}
+
+func TestDistinctOverTimeCountsUniqueValues(t *testing.T) {
+	pv := positronic.NewPositronicVariable(0)
+	pv.Assign(1)
+	pv.Assign(1)
+	pv.Assign(2)
+
+	// timeline: [0] [1] [1] [2], windows of 2: {0,1}->2 distinct, {1,2}->2 distinct
+	distinct := pv.DistinctOverTime(2)
+	if len(distinct) != 2 || distinct[0] != 2 || distinct[1] != 2 {
+		t.Errorf("expected distinct counts [2 2], got %v", distinct)
+	}
+}
+
+func TestHistogramOverTimeBucketsNumericValues(t *testing.T) {
+	pv := positronic.NewPositronicVariable(0)
+	pv.Assign(5)
+	pv.Assign(15)
+
+	hist := pv.HistogramOverTime(3, []float64{10})
+	if len(hist) != 1 {
+		t.Fatalf("expected a single window, got %d", len(hist))
+	}
+	if hist[0].Counts[0] != 2 || hist[0].Counts[1] != 1 {
+		t.Errorf("expected bucket counts [2 1], got %v", hist[0].Counts)
+	}
+}
+
+func TestReduceAppliesFnPerWindow(t *testing.T) {
+	pv := positronic.NewPositronicVariable(0)
+	pv.Assign(1)
+	pv.Assign(2)
+
+	sums := pv.Reduce(2, func(values []interface{}) interface{} {
+		sum := 0
+		for _, v := range values {
+			sum += v.(int)
+		}
+		return sum
+	})
+
+	if len(sums) != 2 || sums[0] != 1 || sums[1] != 2 {
+		t.Errorf("expected sums [1 2], got %v", sums)
+	}
+}
@@ -0,0 +1,52 @@
+package positronic_test
+
+import (
+	"testing"
+
+	"github.com/hutchpd/positronic-variables/pkg/positronic"
+)
+
+func TestDefaultDetectorFindsShortCycle(t *testing.T) {
+	d := positronic.DefaultDetector{MaxCycleLength: 10}
+	seq := []interface{}{0, 1, 2, 0, 1, 2, 0, 1, 2}
+
+	converged, cl := d.Detect(seq)
+	if !converged || cl != 3 {
+		t.Errorf("expected a cycle of length 3, got converged=%v cl=%d", converged, cl)
+	}
+}
+
+func TestBrentDetectorFindsCycleLongerThanDefaultCanSee(t *testing.T) {
+	cycle := []interface{}{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	var seq []interface{}
+	for i := 0; i < 4; i++ {
+		seq = append(seq, cycle...)
+	}
+
+	def := positronic.DefaultDetector{MaxCycleLength: 10}
+	if converged, cl := def.Detect(seq); converged {
+		t.Fatalf("expected DefaultDetector capped at 10 not to find a 12-length cycle, got cl=%d", cl)
+	}
+
+	brent := positronic.BrentDetector{}
+	converged, cl := brent.Detect(seq)
+	if !converged || cl != 12 {
+		t.Errorf("expected BrentDetector to find a cycle of length 12, got converged=%v cl=%d", converged, cl)
+	}
+}
+
+func TestBrentDetectorUsesCustomEqual(t *testing.T) {
+	type wrapped struct{ v int }
+	seq := []interface{}{wrapped{1}, wrapped{2}, wrapped{1}, wrapped{2}}
+
+	brent := positronic.BrentDetector{
+		Equal: func(a, b interface{}) bool {
+			return a.(wrapped).v == b.(wrapped).v
+		},
+	}
+
+	converged, cl := brent.Detect(seq)
+	if !converged || cl != 2 {
+		t.Errorf("expected a cycle of length 2 using the custom Equal, got converged=%v cl=%d", converged, cl)
+	}
+}
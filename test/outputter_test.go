@@ -0,0 +1,55 @@
+package positronic_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hutchpd/positronic-variables/pkg/positronic"
+)
+
+func TestBufferedOutputterCapturesRunProgramOutput(t *testing.T) {
+	buf := &positronic.BufferedOutputter{}
+	pv := positronic.NewPositronicVariable(0, positronic.WithOutputter(buf))
+
+	pv.RunProgram(func(p *positronic.PositronicVariable) {
+		p.Output("value=%v", p.CurrentState())
+		p.Assign(1)
+	})
+
+	if len(buf.Lines) == 0 {
+		t.Fatalf("expected BufferedOutputter to capture at least one Output line")
+	}
+	if _, ok := buf.Final["value=%v"]; !ok {
+		t.Errorf("expected Flush to populate Final with the \"value=%%v\" format, got %v", buf.Final)
+	}
+}
+
+func TestJSONOutputterWritesJSONLines(t *testing.T) {
+	var out bytes.Buffer
+	j := &positronic.JSONOutputter{W: &out}
+
+	j.Emit(1, 1, "value=%v", 42)
+	j.Flush(map[string]interface{}{"value=%v": 42})
+
+	if !strings.Contains(out.String(), `"line":"value=42"`) {
+		t.Errorf("expected emitted JSON line to contain the formatted output, got %q", out.String())
+	}
+}
+
+func TestProgressOutputterLabelsBothDirections(t *testing.T) {
+	var out bytes.Buffer
+	p := &positronic.ProgressOutputter{W: &out}
+
+	p.Emit(0, 1, "x=%v", 1)
+	p.Emit(1, -1, "x=%v", 1)
+
+	got := out.String()
+	if !strings.Contains(got, "forward") || !strings.Contains(got, "backward") {
+		t.Errorf("expected spinner output to label both directions, got %q", got)
+	}
+}
+
+func TestPrintfOutputterFlushDoesNotPanicOnEmptySuperpositions(t *testing.T) {
+	positronic.PrintfOutputter{}.Flush(map[string]interface{}{})
+}
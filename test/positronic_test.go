@@ -11,11 +11,3 @@ func TestNewPositronicVariable(t *testing.T) {
         t.Errorf("Expected initial state to be 0, got %v", pv.CurrentState())
     }
 }
-
-func TestReverseArrowOfTime(t *testing.T) {
-    entropy := 1
-    positronic.ReverseArrowOfTime(&entropy)
-    if entropy != -1 {
-        t.Errorf("Expected entropy to be -1, got %d", entropy)
-    }
-}
@@ -0,0 +1,79 @@
+package positronic_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hutchpd/positronic-variables/pkg/positronic"
+	"github.com/hutchpd/positronic-variables/pkg/positronic/trace"
+)
+
+func TestWithSequentialAppendsAssignmentsInOrder(t *testing.T) {
+	pv := positronic.NewPositronicVariable(0)
+	before := pv.CountOverTime(1)
+
+	pv.WithSequential(
+		func(s *positronic.PositronicVariable) { s.Assign(1) },
+		func(s *positronic.PositronicVariable) { s.Assign(2) },
+	)
+
+	after := pv.CountOverTime(1)
+	if len(after)-len(before) != 2 {
+		t.Errorf("expected WithSequential to advance the timeline by 2 entries, got %d", len(after)-len(before))
+	}
+	if pv.CurrentState() != 2 {
+		t.Errorf("expected current state to be the last sequential assignment (2), got %v", pv.CurrentState())
+	}
+}
+
+func TestWithParallelAlignsPerStepFrontiers(t *testing.T) {
+	pv := positronic.NewPositronicVariable(0)
+	before := pv.CountOverTime(1)
+
+	pv.WithParallel(
+		func(branch *positronic.PositronicVariable) {
+			branch.Assign(1)
+			branch.Assign(2)
+		},
+		func(branch *positronic.PositronicVariable) {
+			branch.Assign(10)
+		},
+	)
+
+	after := pv.CountOverTime(1)
+	got := len(after) - len(before)
+	if got != 2 {
+		t.Errorf("expected WithParallel to advance the timeline by max(branch mu) = 2 aligned entries, got %d", got)
+	}
+}
+
+func TestRunBlockPropagatesTracerToScratch(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := trace.NewJSONLWriter(&buf)
+	pv := positronic.NewPositronicVariable(0, positronic.WithTrace(tracer))
+
+	pv.WithSequential(
+		func(s *positronic.PositronicVariable) { s.Assign(1) },
+	)
+
+	if !strings.Contains(buf.String(), `"kind":"assign"`) {
+		t.Errorf("expected Assign inside WithSequential to be traced via the parent's tracer, got %q", buf.String())
+	}
+}
+
+func TestRunBlockPropagatesOutputterToScratch(t *testing.T) {
+	buf := &positronic.BufferedOutputter{}
+	pv := positronic.NewPositronicVariable(0, positronic.WithOutputter(buf))
+
+	pv.RunProgram(func(p *positronic.PositronicVariable) {
+		p.WithSequential(func(s *positronic.PositronicVariable) {
+			s.Output("tick")
+		})
+		p.Assign(1)
+	})
+
+	if len(buf.Lines) == 0 {
+		t.Errorf("expected Output calls inside WithSequential to reach the parent's Outputter")
+	}
+}
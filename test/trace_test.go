@@ -0,0 +1,58 @@
+package positronic_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hutchpd/positronic-variables/pkg/positronic"
+	"github.com/hutchpd/positronic-variables/pkg/positronic/trace"
+)
+
+func TestJSONLWriterEmitsOneEventPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	w := trace.NewJSONLWriter(&buf)
+
+	if err := w.WriteEvent(trace.Event{Iteration: 1, Entropy: 1, Kind: trace.EventAssign, Args: []interface{}{"x"}}); err != nil {
+		t.Fatalf("WriteEvent returned an error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"kind":"assign"`) {
+		t.Errorf("expected emitted line to contain the event kind, got %q", buf.String())
+	}
+}
+
+func TestChromeTraceWriterClosesAsValidTraceEventsDocument(t *testing.T) {
+	var buf bytes.Buffer
+	w := trace.NewChromeTraceWriter(&buf)
+
+	w.WriteEvent(trace.Event{Iteration: 0, Entropy: 1, Kind: trace.EventIteration})
+	w.WriteEvent(trace.Event{Iteration: 1, Entropy: -1, Kind: trace.EventConvergence, CycleLength: 3})
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("expected valid JSON from Close, got error: %v", err)
+	}
+
+	events, ok := doc["traceEvents"].([]interface{})
+	if !ok || len(events) != 2 {
+		t.Errorf("expected 2 traceEvents, got %v", doc["traceEvents"])
+	}
+}
+
+func TestWithTraceEmitsAssignEvents(t *testing.T) {
+	var buf bytes.Buffer
+	w := trace.NewJSONLWriter(&buf)
+
+	pv := positronic.NewPositronicVariable(0, positronic.WithTrace(w))
+	pv.Assign(1)
+
+	if !strings.Contains(buf.String(), `"kind":"assign"`) {
+		t.Errorf("expected Assign to emit a traced assign event, got %q", buf.String())
+	}
+}
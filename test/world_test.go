@@ -0,0 +1,44 @@
+package positronic_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hutchpd/positronic-variables/pkg/positronic"
+	"github.com/hutchpd/positronic-variables/pkg/positronic/trace"
+)
+
+func TestWorldRunProgramTracksEntropyPerVariable(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := trace.NewJSONLWriter(&buf)
+	a := positronic.NewPositronicVariable(0, positronic.WithTrace(tracer))
+
+	w := positronic.NewWorld()
+	w.Register("a", a)
+
+	w.RunProgram(func(world *positronic.World, entropy int) {
+		a.Assign(1)
+	}, positronic.MaxIterations(4))
+
+	if !strings.Contains(buf.String(), `"entropy":-1`) {
+		t.Errorf("expected a registered variable's traced events to see entropy -1 on backward iterations, got %q", buf.String())
+	}
+}
+
+func TestWorldRunProgramFlushesThroughWorldOutputter(t *testing.T) {
+	buf := &positronic.BufferedOutputter{}
+
+	a := positronic.NewPositronicVariable(0)
+	w := positronic.NewWorld()
+	w.Register("a", a)
+
+	w.RunProgram(func(world *positronic.World, entropy int) {
+		a.Output("value=%v", a.CurrentState())
+		a.Assign(1)
+	}, positronic.WithWorldOutputter(buf), positronic.MaxIterations(4))
+
+	if len(buf.Final) == 0 {
+		t.Errorf("expected World.RunProgram to flush joint superpositions through the installed Outputter instead of stdout")
+	}
+}